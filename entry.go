@@ -0,0 +1,92 @@
+package log
+
+import "fmt"
+
+// Fields is a set of structured key/value pairs attached to a log line.
+type Fields map[string]interface{}
+
+// Entry carries an immutable set of Fields plus the Logger that will
+// eventually write it out. Logger.WithField/WithFields/WithError
+// construct one; copyFields keeps each chained call from mutating its
+// parent's map.
+type Entry struct {
+	logger *Logger
+	Fields Fields
+}
+
+// WithField starts a structured Entry with a single field.
+func (logger *Logger) WithField(k string, v interface{}) *Entry {
+	return &Entry{logger: logger, Fields: Fields{k: v}}
+}
+
+// WithFields starts a structured Entry carrying a copy of fields.
+func (logger *Logger) WithFields(fields Fields) *Entry {
+	return &Entry{logger: logger, Fields: copyFields(fields)}
+}
+
+// WithError starts a structured Entry with an "error" field set to
+// err.Error(), or nil if err is nil.
+func (logger *Logger) WithError(err error) *Entry {
+	if err == nil {
+		return logger.WithField("error", nil)
+	}
+	return logger.WithField("error", err.Error())
+}
+
+// WithField returns a new Entry with k added to the existing fields,
+// leaving the receiver untouched.
+func (e *Entry) WithField(k string, v interface{}) *Entry {
+	fields := copyFields(e.Fields)
+	fields[k] = v
+	return &Entry{logger: e.logger, Fields: fields}
+}
+
+// WithFields returns a new Entry with fields merged into the existing
+// ones, leaving the receiver untouched.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	merged := copyFields(e.Fields)
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Entry{logger: e.logger, Fields: merged}
+}
+
+func (e *Entry) Debug(msg string) {
+	e.logger.logEntry(DEBUG, e.Fields, msg)
+}
+
+func (e *Entry) Info(msg string) {
+	e.logger.logEntry(INFO, e.Fields, msg)
+}
+
+func (e *Entry) Warning(msg string) {
+	e.logger.logEntry(WARNING, e.Fields, msg)
+}
+
+func (e *Entry) Error(msg string) {
+	e.logger.logEntry(ERROR, e.Fields, msg)
+}
+
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	e.logger.logEntry(DEBUG, e.Fields, fmt.Sprintf(format, args...))
+}
+
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.logger.logEntry(INFO, e.Fields, fmt.Sprintf(format, args...))
+}
+
+func (e *Entry) Warningf(format string, args ...interface{}) {
+	e.logger.logEntry(WARNING, e.Fields, fmt.Sprintf(format, args...))
+}
+
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.logger.logEntry(ERROR, e.Fields, fmt.Sprintf(format, args...))
+}
+
+func copyFields(fields Fields) Fields {
+	cp := make(Fields, len(fields))
+	for k, v := range fields {
+		cp[k] = v
+	}
+	return cp
+}
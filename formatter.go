@@ -0,0 +1,74 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// LogEntry is the formatter-facing view of a single log line: a
+// timestamp, level, message, and whatever structured fields were
+// attached via WithField/WithFields/WithError.
+type LogEntry struct {
+	Time   time.Time
+	Level  LogLevel
+	Msg    string
+	Fields Fields
+}
+
+// Formatter renders a LogEntry to the exact bytes a LogWriter should
+// emit, newline included.
+type Formatter interface {
+	Format(entry *LogEntry) (string, error)
+}
+
+// TextFormatter renders the classic "[timestamp] LEVEL: msg" line,
+// appending any fields as sorted "key=value" pairs.
+type TextFormatter struct{}
+
+func (f *TextFormatter) Format(entry *LogEntry) (string, error) {
+	line := formatLine(entry.Time, entry.Level, entry.Msg)
+	if len(entry.Fields) == 0 {
+		return line, nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(line[:len(line)-1]) // drop the trailing newline, re-add below
+	for _, k := range sortedFieldKeys(entry.Fields) {
+		fmt.Fprintf(&buf, " %s=%v", k, entry.Fields[k])
+	}
+	buf.WriteByte('\n')
+
+	return buf.String(), nil
+}
+
+// JSONFormatter renders one JSON object per line with "time", "level"
+// and "msg" keys, merged with the entry's fields.
+type JSONFormatter struct{}
+
+func (f *JSONFormatter) Format(entry *LogEntry) (string, error) {
+	data := make(map[string]interface{}, len(entry.Fields)+3)
+	for k, v := range entry.Fields {
+		data[k] = v
+	}
+	data["time"] = entry.Time.Format(TimeFORMAT)
+	data["level"] = levelToString(entry.Level)
+	data["msg"] = entry.Msg
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+func sortedFieldKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
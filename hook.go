@@ -0,0 +1,96 @@
+package log
+
+import (
+	"fmt"
+	"os"
+)
+
+func logHookError(h Hook, err error) {
+	fmt.Fprintf(os.Stderr, "log: hook %T failed: %v\n", h, err)
+}
+
+// Hook lets external code observe log entries without modifying the
+// logger core. Fire is called once per entry, after formatting, for
+// every level the hook declares interest in via Levels. Hooks run on a
+// separate goroutine; entries that arrive while its queue is full are
+// not passed to any hook.
+type Hook interface {
+	Levels() []LogLevel
+	Fire(entry *LogEntry) error
+}
+
+// AddHook registers h to be fired for every subsequent entry at a level
+// in h.Levels(), apart from entries dropped by enqueueHooks.
+func (logger *Logger) AddHook(h Hook) {
+	core := logger.core
+	core.mu.Lock()
+	defer core.mu.Unlock()
+	core.hooks = append(core.hooks, h)
+}
+
+// enqueueHooks hands entry to the hook worker without blocking: a slow
+// or unreachable hook sink must never back-pressure the drain goroutine
+// (and, transitively, every logging caller). If the hook queue is full,
+// the entry is simply skipped for hooks — it is still written to every
+// LogWriter as normal.
+func (core *loggerCore) enqueueHooks(entry *LogEntry) {
+	core.mu.Lock()
+	hasHooks := len(core.hooks) > 0
+	core.mu.Unlock()
+	if !hasHooks {
+		return
+	}
+
+	select {
+	case core.hookChan <- entry:
+	default:
+		fmt.Fprintln(os.Stderr, "log: hook queue full, dropping entry for hooks")
+	}
+}
+
+// runHooks is the single goroutine that drains hookChan and fires each
+// matching Hook, off the main write path.
+func (core *loggerCore) runHooks() {
+	defer core.hookWg.Done()
+	for entry := range core.hookChan {
+		core.mu.Lock()
+		hooks := core.hooks
+		core.mu.Unlock()
+
+		for _, h := range hooks {
+			if !levelsContain(h.Levels(), entry.Level) {
+				continue
+			}
+			if err := h.Fire(entry); err != nil {
+				logHookError(h, err)
+			}
+		}
+	}
+}
+
+func levelsContain(levels []LogLevel, level LogLevel) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// CallbackHook adapts a plain function to the Hook interface, firing it
+// for every level in ForLevels (all levels when empty).
+type CallbackHook struct {
+	ForLevels []LogLevel
+	Callback  func(entry *LogEntry) error
+}
+
+func (h *CallbackHook) Levels() []LogLevel {
+	if len(h.ForLevels) == 0 {
+		return []LogLevel{DEBUG, INFO, WARNING, ERROR}
+	}
+	return h.ForLevels
+}
+
+func (h *CallbackHook) Fire(entry *LogEntry) error {
+	return h.Callback(entry)
+}
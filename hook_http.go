@@ -0,0 +1,73 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPHook POSTs each matching entry as a JSON object to URL, retrying
+// with exponential backoff up to MaxRetries times before giving up.
+type HTTPHook struct {
+	URL        string
+	ForLevels  []LogLevel
+	MaxRetries int
+	RetryDelay time.Duration
+	Client     *http.Client
+}
+
+// NewHTTPHook returns an HTTPHook posting to url for the given levels,
+// with 3 retries and a 500ms initial backoff.
+func NewHTTPHook(url string, levels ...LogLevel) *HTTPHook {
+	return &HTTPHook{
+		URL:        url,
+		ForLevels:  levels,
+		MaxRetries: 3,
+		RetryDelay: 500 * time.Millisecond,
+		Client:     &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (h *HTTPHook) Levels() []LogLevel {
+	if len(h.ForLevels) == 0 {
+		return []LogLevel{DEBUG, INFO, WARNING, ERROR}
+	}
+	return h.ForLevels
+}
+
+func (h *HTTPHook) Fire(entry *LogEntry) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"time":   entry.Time.Format(TimeFORMAT),
+		"level":  levelToString(entry.Level),
+		"msg":    entry.Msg,
+		"fields": entry.Fields,
+	})
+	if err != nil {
+		return err
+	}
+
+	delay := h.RetryDelay
+	var lastErr error
+	for attempt := 0; attempt <= h.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		resp, err := h.Client.Post(h.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("log: http hook got status %d", resp.StatusCode)
+	}
+
+	return lastErr
+}
@@ -20,142 +20,358 @@ const TimeFORMAT = "2006-01-02 15:04:05"
 type logEntry struct {
 	level  LogLevel
 	msg    string
+	fields Fields
 	logger *Logger
 }
 
+// loggerCore holds everything a Logger fans entries out through:
+// writers, hooks, the formatter, and the queue/flush machinery. It is
+// shared by a Logger and every child produced by Named, so writers are
+// opened once no matter how many named handles log through them.
+type loggerCore struct {
+	logChan   chan *logEntry
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	writers   []*nameWriter
+	formatter Formatter
+	hooks     []Hook
+
+	dropOnFull bool
+	stopFlush  chan struct{}
+
+	// hookChan decouples hook firing from the drain goroutine above: a
+	// slow or unreachable hook sink (e.g. HTTPHook's retry/backoff)
+	// would otherwise stall writeLogEntry, fill logChan, and then block
+	// every caller under BlockOnFull. hookChan is always drop-on-full,
+	// since missing a hook delivery must never back-pressure logging.
+	hookChan chan *LogEntry
+	hookWg   sync.WaitGroup
+
+	sendMu sync.RWMutex
+	closed bool
+}
+
+// DefaultHookQueueSize is hookChan's capacity.
+const DefaultHookQueueSize = 100
+
+// Logger is a handle onto a shared loggerCore. Its own level gates
+// which entries are queued at all; its component name (set via Named)
+// is attached to every entry it emits, so multiple components can log
+// through the same writers without being confused for one another.
 type Logger struct {
-	level         LogLevel
-	timeFormat    string
-	logChan       chan *logEntry
-	wg            sync.WaitGroup
-	file          *os.File
-	filename      string
-	maxFileSize   int64
-	maxFileBackup int
-}
-
-var instance *Logger
-var once sync.Once
-
-func NewLogger(level LogLevel, filename string, maxFileSizeMB int64, maxFileBackupMB int) (*Logger, error) {
-	var err error
-	once.Do(func() {
-		instance = &Logger{
-			level:         level,
-			timeFormat:    TimeFORMAT,
-			logChan:       make(chan *logEntry),
-			filename:      filename,
-			maxFileSize:   maxFileSizeMB * 1024 * 1024,
-			maxFileBackup: maxFileBackupMB * 1024 * 1024,
-		}
-		if err = instance.initFile(); err != nil {
-			return
+	level     LogLevel
+	component string
+	core      *loggerCore
+}
+
+// Options configures the queuing and flushing behavior of a Logger.
+// The zero value is usable: a 1000-entry queue, BlockOnFull backpressure,
+// and a 1s flush interval.
+type Options struct {
+	// QueueSize bounds logChan. 0 means DefaultQueueSize.
+	QueueSize int
+	// DropOnFull makes a full queue drop the entry instead of blocking
+	// the caller (BlockOnFull, the default).
+	DropOnFull bool
+	// FlushInterval is how often buffered writers are flushed. 0 means
+	// DefaultFlushInterval; negative disables periodic flushing.
+	FlushInterval time.Duration
+}
+
+// DefaultQueueSize is the logChan capacity used when Options is nil or
+// its QueueSize is 0.
+const DefaultQueueSize = 1000
+
+// DefaultFlushInterval is how often buffered writers are flushed when
+// Options is nil or its FlushInterval is 0.
+const DefaultFlushInterval = time.Second
+
+var defaultMu sync.RWMutex
+var defaultLogger *Logger
+
+// Default returns the Logger package-level Debug/Info/Warning/Error
+// write through. It is nil until a Logger is created and either it's
+// the first one made with NewLogger, or SetDefault is called.
+func Default() *Logger {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLogger
+}
+
+// SetDefault swaps the Logger used by the package-level helpers.
+func SetDefault(l *Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLogger = l
+}
+
+// NewLogger creates a fresh, independent Logger at level that fans out
+// to the writers described by configs. Each config's Name must refer to
+// a writer registered via Register (e.g. "console", "file", "syslog",
+// "conn"). Further writers can be attached later with AddWriter. opts
+// may be nil to take the defaults described on Options.
+//
+// The first Logger created in a process becomes the Default(); call
+// SetDefault to change that.
+func NewLogger(level LogLevel, opts *Options, configs ...WriterConfig) (*Logger, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	flushInterval := opts.FlushInterval
+	if flushInterval == 0 {
+		flushInterval = DefaultFlushInterval
+	}
+
+	core := &loggerCore{
+		logChan:    make(chan *logEntry, queueSize),
+		formatter:  &TextFormatter{},
+		dropOnFull: opts.DropOnFull,
+		stopFlush:  make(chan struct{}),
+		hookChan:   make(chan *LogEntry, DefaultHookQueueSize),
+	}
+	logger := &Logger{level: level, core: core}
+
+	for _, cfg := range configs {
+		if err := logger.AddWriter(cfg.Name, cfg.Config); err != nil {
+			return nil, err
 		}
-		go instance.writeLogEntries()
-	})
-	return instance, err
+	}
+
+	core.wg.Add(1)
+	go core.writeLogEntries()
+
+	core.hookWg.Add(1)
+	go core.runHooks()
+
+	if flushInterval > 0 {
+		go core.runFlushLoop(flushInterval)
+	}
+
+	defaultMu.Lock()
+	if defaultLogger == nil {
+		defaultLogger = logger
+	}
+	defaultMu.Unlock()
+
+	return logger, nil
+}
+
+// Named returns a child Logger that shares this Logger's writers, hooks
+// and formatter but tags every entry it emits with a "component" field
+// of sub (dotted onto this Logger's own component, if any).
+func (logger *Logger) Named(sub string) *Logger {
+	component := sub
+	if logger.component != "" {
+		component = logger.component + "." + sub
+	}
+	return &Logger{level: logger.level, component: component, core: logger.core}
 }
+
 func Debug(format string, args ...interface{}) {
-	instance.debugf(format, args)
+	Default().Debug(format, args...)
 }
 func Info(format string, args ...interface{}) {
-	instance.infof(format, args)
+	Default().Info(format, args...)
 }
 func Warning(format string, args ...interface{}) {
-	instance.infof(format, args)
+	Default().Warning(format, args...)
 }
 func Error(format string, args ...interface{}) {
-	instance.errorf(format, args)
+	Default().Error(format, args...)
 }
 func (logger *Logger) logf(level LogLevel, format string, args ...interface{}) {
+	logger.logEntry(level, nil, fmt.Sprintf(format, args...))
+}
+
+// logEntry is the single entry point all logging APIs funnel through:
+// the package-level helpers, Logger.Debug/Info/..., and Entry's
+// field-carrying methods.
+func (logger *Logger) logEntry(level LogLevel, fields Fields, msg string) {
 	if logger.level > level {
 		return
 	}
 
+	if logger.component != "" {
+		tagged := make(Fields, len(fields)+1)
+		for k, v := range fields {
+			tagged[k] = v
+		}
+		tagged["component"] = logger.component
+		fields = tagged
+	}
+
 	entry := &logEntry{
 		level:  level,
-		msg:    fmt.Sprintf(format, args...),
+		msg:    msg,
+		fields: fields,
 		logger: logger,
 	}
 
-	logger.logChan <- entry
+	core := logger.core
+	core.sendMu.RLock()
+	defer core.sendMu.RUnlock()
+	if core.closed {
+		return
+	}
+
+	if core.dropOnFull {
+		select {
+		case core.logChan <- entry:
+		default:
+			fmt.Fprintln(os.Stderr, "log: queue full, dropping entry")
+		}
+		return
+	}
+
+	core.logChan <- entry
+}
+
+// SetFormatter replaces the Formatter used to render entries before
+// they are handed to the writers.
+func (logger *Logger) SetFormatter(f Formatter) {
+	core := logger.core
+	core.mu.Lock()
+	defer core.mu.Unlock()
+	core.formatter = f
+}
+
+func (core *loggerCore) getFormatter() Formatter {
+	core.mu.Lock()
+	defer core.mu.Unlock()
+	return core.formatter
 }
 
-func (logger *Logger) debugf(format string, args ...interface{}) {
+// Debug, Info, Warning, and Error log a formatted message through this
+// Logger specifically, independent of Default() — the way to write to a
+// second Logger (e.g. a separate audit log) without swapping the
+// package-level default.
+func (logger *Logger) Debug(format string, args ...interface{}) {
 	logger.logf(DEBUG, format, args...)
 }
 
-func (logger *Logger) infof(format string, args ...interface{}) {
+func (logger *Logger) Info(format string, args ...interface{}) {
 	logger.logf(INFO, format, args...)
 }
 
-func (logger *Logger) warningf(format string, args ...interface{}) {
+func (logger *Logger) Warning(format string, args ...interface{}) {
 	logger.logf(WARNING, format, args...)
 }
 
-func (logger *Logger) errorf(format string, args ...interface{}) {
+func (logger *Logger) Error(format string, args ...interface{}) {
 	logger.logf(ERROR, format, args...)
 }
 
-func (logger *Logger) writeLogEntries() {
-	for entry := range logger.logChan {
-		logger.wg.Add(1)
-		go func(entry *logEntry) {
-			defer logger.wg.Done()
-			logger.writeLogEntry(entry)
-		}(entry)
+// writeLogEntries is the single goroutine that drains logChan. Entries
+// are written one at a time, in the order they were queued, so multiple
+// writers never see interleaved or reordered lines.
+func (core *loggerCore) writeLogEntries() {
+	defer core.wg.Done()
+	for entry := range core.logChan {
+		core.writeLogEntry(entry)
 	}
-	logger.wg.Wait()
 }
 
-func (logger *Logger) writeLogEntry(entry *logEntry) {
-	msg := entry.msg
-	timestamp := time.Now().Format(logger.timeFormat)
-	logLine := fmt.Sprintf("[%s] %s: %s\n", timestamp, levelToString(entry.level), msg)
-
-	if _, err := logger.file.Write([]byte(logLine)); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing log file: %v", err)
+// runFlushLoop periodically flushes every writer until Close stops it.
+func (core *loggerCore) runFlushLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			core.flush()
+		case <-core.stopFlush:
+			return
+		}
 	}
-	logger.checkFileRotation()
 }
 
-func (logger *Logger) checkFileRotation() {
-	fileInfo, err := logger.file.Stat()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error checking log file size: %v", err)
-		return
-	}
+// Flush flushes every registered writer.
+func (logger *Logger) Flush() {
+	logger.core.flush()
+}
 
-	if fileInfo.Size() >= logger.maxFileSize {
-		if err := logger.rotateLogFile(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error rotating log file: %v", err)
-		}
+func (core *loggerCore) flush() {
+	core.mu.Lock()
+	writers := core.writers
+	core.mu.Unlock()
+
+	for _, w := range writers {
+		w.Flush()
 	}
 }
-func (logger *Logger) rotateLogFile() error {
-	logger.file.Close()
 
-	// Rename backup files
-	for i := logger.maxFileBackup - 1; i >= 0; i-- {
-		oldName := fmt.Sprintf("%s.%d", logger.filename, i)
-		newName := fmt.Sprintf("%s.%d", logger.filename, i+1)
-		os.Rename(oldName, newName)
+// Close stops accepting new entries, drains the queue, flushes and
+// closes every writer, and waits for all of that to finish. Calling
+// Close on any Logger sharing this core affects every other handle
+// (Named children included); it is safe to call more than once.
+func (logger *Logger) Close() error {
+	core := logger.core
+
+	core.sendMu.Lock()
+	if core.closed {
+		core.sendMu.Unlock()
+		return nil
 	}
+	core.closed = true
+	close(core.logChan)
+	core.sendMu.Unlock()
+
+	close(core.stopFlush)
+	core.wg.Wait()
+	core.flush()
+
+	close(core.hookChan)
+	core.hookWg.Wait()
 
-	// Rename current file
-	os.Rename(logger.filename, fmt.Sprintf("%s.1", logger.filename))
+	core.mu.Lock()
+	writers := core.writers
+	core.mu.Unlock()
 
-	return logger.initFile()
+	for _, w := range writers {
+		w.Close()
+	}
+	return nil
 }
-func (logger *Logger) initFile() error {
-	file, err := os.OpenFile(logger.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0660)
+
+func (core *loggerCore) writeLogEntry(entry *logEntry) {
+	when := time.Now()
+
+	logEnt := &LogEntry{
+		Time:   when,
+		Level:  entry.level,
+		Msg:    entry.msg,
+		Fields: entry.fields,
+	}
+
+	rendered, err := core.getFormatter().Format(logEnt)
 	if err != nil {
-		return fmt.Errorf("error opening log file: %v", err)
+		fmt.Fprintf(os.Stderr, "log: formatter failed: %v", err)
+		return
 	}
-	logger.file = file
 
-	return nil
+	core.enqueueHooks(logEnt)
+
+	core.mu.Lock()
+	writers := core.writers
+	core.mu.Unlock()
+
+	for _, w := range writers {
+		if err := w.WriteMsg(when, entry.level, rendered); err != nil {
+			fmt.Fprintf(os.Stderr, "log: writer %q failed: %v", w.name, err)
+		}
+	}
 }
+
+// formatLine renders the classic "[timestamp] LEVEL: msg\n" line used
+// by TextFormatter.
+func formatLine(when time.Time, level LogLevel, msg string) string {
+	return fmt.Sprintf("[%s] %s: %s\n", when.Format(TimeFORMAT), levelToString(level), msg)
+}
+
 func levelToString(level LogLevel) string {
 	switch level {
 	case DEBUG:
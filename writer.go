@@ -0,0 +1,73 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LogWriter is implemented by every output backend a Logger can fan out
+// to. Init receives the backend's own config (typically a JSON blob),
+// WriteMsg delivers a single formatted entry, and Flush/Close are called
+// on shutdown or explicit flush so buffered writers can drain.
+type LogWriter interface {
+	Init(config string) error
+	WriteMsg(when time.Time, level LogLevel, msg string) error
+	Flush()
+	Close()
+}
+
+type logWriterCreator func() LogWriter
+
+var adapters = make(map[string]logWriterCreator)
+var adaptersMu sync.Mutex
+
+// Register makes a LogWriter creator available under name, so it can be
+// selected by WriterConfig.Name / AddWriter. Register panics if called
+// twice for the same name, mirroring the database/sql driver pattern.
+func Register(name string, creator logWriterCreator) {
+	adaptersMu.Lock()
+	defer adaptersMu.Unlock()
+
+	if creator == nil {
+		panic("log: Register creator is nil")
+	}
+	if _, dup := adapters[name]; dup {
+		panic("log: Register called twice for writer " + name)
+	}
+	adapters[name] = creator
+}
+
+// WriterConfig names a registered LogWriter adapter and carries its
+// per-backend config string, typically JSON.
+type WriterConfig struct {
+	Name   string
+	Config string
+}
+
+type nameWriter struct {
+	name string
+	LogWriter
+}
+
+// AddWriter instantiates the writer registered under name, initializes
+// it with jsonConfig, and adds it to the logger's output set. It is safe
+// to call after the logger is already writing.
+func (logger *Logger) AddWriter(name string, jsonConfig string) error {
+	creator, ok := adapters[name]
+	if !ok {
+		return fmt.Errorf("log: unknown writer %q (forgotten Register?)", name)
+	}
+
+	w := creator()
+	if err := w.Init(jsonConfig); err != nil {
+		return fmt.Errorf("log: failed to init writer %q: %v", name, err)
+	}
+
+	core := logger.core
+	core.mu.Lock()
+	core.writers = append(core.writers, &nameWriter{name: name, LogWriter: w})
+	core.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+func init() {
+	Register("conn", newConnWriter)
+}
+
+// connWriterConfig is the JSON shape accepted by ConnWriter.Init.
+type connWriterConfig struct {
+	Net            string `json:"net"` // "tcp", "tcp4", "tcp6"; defaults to "tcp"
+	Addr           string `json:"addr"`
+	ReconnectOnMsg bool   `json:"reconnectOnMsg"`   // dial a fresh connection before every message
+	ReconnectOnErr bool   `json:"reconnectOnError"` // redial once and retry when a write fails
+}
+
+// ConnWriter ships log lines over a persistent TCP (or other
+// net.Conn-based) connection, redialing according to its reconnect
+// policy when the peer drops or a write fails.
+type ConnWriter struct {
+	mu             sync.Mutex
+	network        string
+	addr           string
+	reconnectOnMsg bool
+	reconnectOnErr bool
+	conn           net.Conn
+}
+
+func newConnWriter() LogWriter {
+	return &ConnWriter{}
+}
+
+func (w *ConnWriter) Init(config string) error {
+	cfg := connWriterConfig{Net: "tcp"}
+	if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+		return err
+	}
+	if cfg.Addr == "" {
+		return fmt.Errorf("log: conn writer requires an addr")
+	}
+
+	w.network = cfg.Net
+	w.addr = cfg.Addr
+	w.reconnectOnMsg = cfg.ReconnectOnMsg
+	w.reconnectOnErr = cfg.ReconnectOnErr
+
+	if !w.reconnectOnMsg {
+		return w.dial()
+	}
+	return nil
+}
+
+func (w *ConnWriter) dial() error {
+	conn, err := net.Dial(w.network, w.addr)
+	if err != nil {
+		return err
+	}
+	if w.conn != nil {
+		w.conn.Close()
+	}
+	w.conn = conn
+	return nil
+}
+
+func (w *ConnWriter) WriteMsg(when time.Time, level LogLevel, msg string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.reconnectOnMsg {
+		if err := w.dial(); err != nil {
+			return err
+		}
+	}
+	if w.conn == nil {
+		if err := w.dial(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.conn.Write([]byte(msg)); err != nil {
+		if !w.reconnectOnErr {
+			return err
+		}
+		if dialErr := w.dial(); dialErr != nil {
+			return dialErr
+		}
+		_, err = w.conn.Write([]byte(msg))
+		return err
+	}
+	return nil
+}
+
+func (w *ConnWriter) Flush() {}
+
+func (w *ConnWriter) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn != nil {
+		w.conn.Close()
+	}
+}
@@ -0,0 +1,63 @@
+package log
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+func init() {
+	Register("console", newConsoleWriter)
+}
+
+// ansi color codes per log level, matching the conventional
+// debug=cyan, info=green, warning=yellow, error=red scheme.
+var colorByLevel = map[LogLevel]string{
+	DEBUG:   "\033[36m",
+	INFO:    "\033[32m",
+	WARNING: "\033[33m",
+	ERROR:   "\033[31m",
+}
+
+const colorReset = "\033[0m"
+
+// consoleWriterConfig is the JSON shape accepted by ConsoleWriter.Init.
+type consoleWriterConfig struct {
+	Color bool `json:"color"`
+}
+
+// ConsoleWriter writes log lines to stderr, optionally colorizing each
+// line by level.
+type ConsoleWriter struct {
+	color bool
+}
+
+func newConsoleWriter() LogWriter {
+	return &ConsoleWriter{}
+}
+
+func (w *ConsoleWriter) Init(config string) error {
+	if config == "" {
+		return nil
+	}
+	var cfg consoleWriterConfig
+	if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+		return err
+	}
+	w.color = cfg.Color
+	return nil
+}
+
+// WriteMsg writes msg, which is already fully rendered by the logger's
+// Formatter, colorizing it by level when enabled.
+func (w *ConsoleWriter) WriteMsg(when time.Time, level LogLevel, msg string) error {
+	if w.color {
+		msg = colorByLevel[level] + msg + colorReset
+	}
+	_, err := os.Stderr.WriteString(msg)
+	return err
+}
+
+func (w *ConsoleWriter) Flush() {}
+
+func (w *ConsoleWriter) Close() {}
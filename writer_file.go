@@ -0,0 +1,248 @@
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	Register("file", newFileWriter)
+}
+
+// RotateMode selects how FileWriter handles a rotation trigger (size,
+// line count, or a new calendar day).
+type RotateMode int
+
+const (
+	// APPEND never rotates; the file grows without bound.
+	APPEND RotateMode = iota
+	// TRUNC truncates the file in place, discarding old content.
+	TRUNC
+	// BACKUP keeps a single rotated copy at filename.bak, overwriting
+	// whatever was there before.
+	BACKUP
+	// ROTATE keeps numbered backups filename.1..maxFileBackup, the
+	// legacy size-rotation behavior.
+	ROTATE
+	// DAILY renames the outgoing file to filename.YYYY-MM-DD, keyed to
+	// the date the file was opened rather than a trigger count. The
+	// line whose date triggers the rotation is the last one written to
+	// the outgoing file.
+	DAILY
+)
+
+// fileWriterConfig is the JSON shape accepted by FileWriter.Init.
+// MaxSizeMB and MaxBackup preserve the sizes/semantics of the original
+// hard-wired rotation logic.
+type fileWriterConfig struct {
+	Filename  string     `json:"filename"`
+	MaxSizeMB int64      `json:"maxsize"`
+	MaxBackup int        `json:"maxbackup"`
+	MaxLines  int64      `json:"maxlines"`
+	Daily     bool       `json:"daily"`
+	MaxDays   int        `json:"maxdays"`
+	Mode      RotateMode `json:"mode"`
+}
+
+// FileWriter writes log lines to a file, rotating it when it exceeds
+// maxFileSize bytes, exceeds maxLines lines, or (when daily is set)
+// when the calendar date changes. mode controls how a size or line-count
+// rotation is carried out; a date change always rotates the DAILY way,
+// and APPEND disables every rotation. Old backups beyond maxDays are
+// pruned after rotating.
+// mu guards file/buf and the counters below, since WriteMsg (from the
+// logger's writer goroutine) and Flush (from the periodic flush
+// goroutine) run concurrently.
+type FileWriter struct {
+	mu            sync.Mutex
+	file          *os.File
+	buf           *bufio.Writer
+	filename      string
+	maxFileSize   int64
+	maxFileBackup int
+	maxLines      int64
+	daily         bool
+	maxDays       int
+	mode          RotateMode
+
+	lineCount int64
+	byteCount int64
+	openDay   string
+}
+
+func newFileWriter() LogWriter {
+	return &FileWriter{}
+}
+
+func (w *FileWriter) Init(config string) error {
+	cfg := fileWriterConfig{Mode: ROTATE}
+	if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+		return err
+	}
+	if cfg.Filename == "" {
+		return fmt.Errorf("log: file writer requires a filename")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.filename = cfg.Filename
+	w.maxFileSize = cfg.MaxSizeMB * 1024 * 1024
+	w.maxFileBackup = cfg.MaxBackup
+	w.maxLines = cfg.MaxLines
+	w.daily = cfg.Daily
+	w.maxDays = cfg.MaxDays
+	w.mode = cfg.Mode
+
+	return w.initFile()
+}
+
+// WriteMsg buffers msg, which is already fully rendered by the logger's
+// Formatter, then checks whether the file needs to be rotated. The
+// buffer is drained by Flush, called periodically by the Logger and
+// once more during Logger.Close; mu keeps those two goroutines from
+// touching file/buf at the same time.
+func (w *FileWriter) WriteMsg(when time.Time, level LogLevel, msg string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.buf.WriteString(msg); err != nil {
+		return err
+	}
+	w.lineCount++
+	w.byteCount += int64(len(msg))
+
+	return w.checkRotation(when)
+}
+
+func (w *FileWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Flush()
+	w.file.Sync()
+}
+
+func (w *FileWriter) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Flush()
+	w.file.Close()
+}
+
+// checkRotation assumes the caller holds mu.
+func (w *FileWriter) checkRotation(when time.Time) error {
+	if w.mode == APPEND {
+		return nil
+	}
+
+	if w.daily && when.Format("2006-01-02") != w.openDay {
+		if err := w.rotate(DAILY); err != nil {
+			return fmt.Errorf("error rotating log file: %v", err)
+		}
+		return nil
+	}
+
+	if (w.maxFileSize > 0 && w.sizeExceeded()) || (w.maxLines > 0 && w.lineCount >= w.maxLines) {
+		if err := w.rotate(w.mode); err != nil {
+			return fmt.Errorf("error rotating log file: %v", err)
+		}
+	}
+	return nil
+}
+
+func (w *FileWriter) sizeExceeded() bool {
+	return w.byteCount >= w.maxFileSize
+}
+
+// rotate flushes and closes the current file, moves it aside according
+// to mode, reopens filename fresh, and prunes backups older than
+// maxDays. Assumes the caller holds mu.
+func (w *FileWriter) rotate(mode RotateMode) error {
+	w.buf.Flush()
+	w.file.Close()
+
+	switch mode {
+	case TRUNC:
+		if err := os.Truncate(w.filename, 0); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	case BACKUP:
+		os.Rename(w.filename, w.filename+".bak")
+	case DAILY:
+		os.Rename(w.filename, fmt.Sprintf("%s.%s", w.filename, w.openDay))
+	default: // ROTATE
+		for i := w.maxFileBackup - 1; i >= 0; i-- {
+			oldName := fmt.Sprintf("%s.%d", w.filename, i)
+			newName := fmt.Sprintf("%s.%d", w.filename, i+1)
+			os.Rename(oldName, newName)
+		}
+		os.Rename(w.filename, fmt.Sprintf("%s.1", w.filename))
+	}
+
+	if err := w.initFile(); err != nil {
+		return err
+	}
+
+	w.pruneOldBackups()
+	return nil
+}
+
+// pruneOldBackups deletes rotated copies of filename older than maxDays.
+// It is a no-op when maxDays is 0 (retain forever). Assumes the caller
+// holds mu.
+func (w *FileWriter) pruneOldBackups() {
+	if w.maxDays <= 0 {
+		return
+	}
+
+	dir := filepath.Dir(w.filename)
+	base := filepath.Base(w.filename)
+	cutoff := time.Now().AddDate(0, 0, -w.maxDays)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == base || !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		os.Remove(filepath.Join(dir, name))
+	}
+}
+
+// initFile assumes the caller holds mu.
+func (w *FileWriter) initFile() error {
+	file, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0660)
+	if err != nil {
+		return fmt.Errorf("error opening log file: %v", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error stating log file: %v", err)
+	}
+
+	w.file = file
+	w.buf = bufio.NewWriter(file)
+	w.lineCount = 0
+	w.byteCount = info.Size()
+	w.openDay = time.Now().Format("2006-01-02")
+
+	return nil
+}
@@ -0,0 +1,69 @@
+//go:build !windows && !plan9 && !nacl
+
+package log
+
+import (
+	"encoding/json"
+	"log/syslog"
+	"time"
+)
+
+func init() {
+	Register("syslog", newSyslogWriter)
+}
+
+// syslogWriterConfig is the JSON shape accepted by SyslogWriter.Init.
+// Network/Addr empty means "dial the local syslog daemon".
+type syslogWriterConfig struct {
+	Network string `json:"network"`
+	Addr    string `json:"addr"`
+	Tag     string `json:"tag"`
+}
+
+// SyslogWriter forwards entries to the system log via log/syslog,
+// mapping each LogLevel to the matching syslog severity.
+type SyslogWriter struct {
+	writer *syslog.Writer
+}
+
+func newSyslogWriter() LogWriter {
+	return &SyslogWriter{}
+}
+
+func (w *SyslogWriter) Init(config string) error {
+	cfg := syslogWriterConfig{Tag: "flog"}
+	if config != "" {
+		if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+			return err
+		}
+	}
+
+	writer, err := syslog.Dial(cfg.Network, cfg.Addr, syslog.LOG_DEBUG|syslog.LOG_USER, cfg.Tag)
+	if err != nil {
+		return err
+	}
+	w.writer = writer
+
+	return nil
+}
+
+func (w *SyslogWriter) WriteMsg(when time.Time, level LogLevel, msg string) error {
+	switch level {
+	case DEBUG:
+		return w.writer.Debug(msg)
+	case INFO:
+		return w.writer.Info(msg)
+	case WARNING:
+		return w.writer.Warning(msg)
+	case ERROR:
+		return w.writer.Err(msg)
+	default:
+		return w.writer.Info(msg)
+	}
+}
+
+func (w *SyslogWriter) Flush() {}
+
+func (w *SyslogWriter) Close() {
+	w.writer.Close()
+}